@@ -0,0 +1,47 @@
+package entity
+
+import "errors"
+
+// Agent bundles a system prompt with the subset of tools it's allowed to
+// call and the default completion config it runs with, so tool availability
+// and system prompts are scoped per agent instead of being global.
+type Agent struct {
+	ID            string
+	Name          string
+	SystemPrompt  string
+	AllowedTools  []string
+	DefaultConfig ChatConfig
+}
+
+func NewAgent(name, systemPrompt string, allowedTools []string, defaultConfig ChatConfig) (*Agent, error) {
+	agent := &Agent{
+		Name:          name,
+		SystemPrompt:  systemPrompt,
+		AllowedTools:  allowedTools,
+		DefaultConfig: defaultConfig,
+	}
+	if err := agent.Validate(); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+func (a *Agent) Validate() error {
+	if a.Name == "" {
+		return errors.New("agent name cannot be empty")
+	}
+	if a.SystemPrompt == "" {
+		return errors.New("agent system prompt cannot be empty")
+	}
+	return nil
+}
+
+// AllowsTool reports whether name is in the agent's tool allow-list.
+func (a *Agent) AllowsTool(name string) bool {
+	for _, allowed := range a.AllowedTools {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Chat is a tree of Messages rather than a flat list: every Message points
+// at its ParentID, and ActiveLeafID names the tip of whichever branch is
+// currently "live". Editing and resending a message forks a new branch off
+// its parent instead of overwriting history.
+type Chat struct {
+	ID                   string
+	UserID               string
+	Title                string
+	InitialSystemMessage *Message
+	Messages             []*Message
+	MessagesByID         map[string]*Message
+	ActiveLeafID         string
+	Status               string
+	TokenUsage           int
+	Config               *ChatConfig
+}
+
+func NewChat(userID string, initialSystemMessage *Message, chatConfig *ChatConfig) (*Chat, error) {
+	chat := &Chat{
+		ID:                   uuid.New().String(),
+		UserID:               userID,
+		InitialSystemMessage: initialSystemMessage,
+		Status:               "active",
+		Config:               chatConfig,
+		MessagesByID:         make(map[string]*Message),
+	}
+	if err := chat.AddMessage(initialSystemMessage); err != nil {
+		return nil, err
+	}
+	if err := chat.Validate(); err != nil {
+		return nil, err
+	}
+	return chat, nil
+}
+
+func (c *Chat) Validate() error {
+	if c.UserID == "" || c.Status == "" || c.Config == nil {
+		return errors.New("invalid chat: missing required fields")
+	}
+	if c.Status != "active" && c.Status != "ended" {
+		return errors.New("invalid status: " + c.Status)
+	}
+	return nil
+}
+
+// AddMessage appends m as a child of the current active leaf (or as the
+// root message if there is none yet) and makes it the new active leaf.
+func (c *Chat) AddMessage(m *Message) error {
+	if c.Status == "ended" {
+		return errors.New("chat is ended, no more messages allowed")
+	}
+	if c.MessagesByID == nil {
+		c.MessagesByID = make(map[string]*Message)
+	}
+	if c.ActiveLeafID != "" {
+		m.ParentID = c.ActiveLeafID
+	}
+	c.MessagesByID[m.ID] = m
+	c.Messages = append(c.Messages, m)
+	c.ActiveLeafID = m.ID
+	c.refreshTokenUsage()
+	return nil
+}
+
+// ActivePath walks parent pointers from the active leaf back to the root and
+// returns them root-first — the slice sent to the provider as context.
+func (c *Chat) ActivePath() []*Message {
+	return c.pathTo(c.ActiveLeafID)
+}
+
+func (c *Chat) pathTo(leafID string) []*Message {
+	var path []*Message
+	for id := leafID; id != ""; {
+		m, ok := c.MessagesByID[id]
+		if !ok {
+			break
+		}
+		path = append([]*Message{m}, path...)
+		id = m.ParentID
+	}
+	return path
+}
+
+// Siblings returns every message that branches off parentID, in the order
+// they were added, so a UI can offer them as alternatives to switch between.
+func (c *Chat) Siblings(parentID string) []*Message {
+	var siblings []*Message
+	for _, m := range c.Messages {
+		if m.ParentID == parentID {
+			siblings = append(siblings, m)
+		}
+	}
+	return siblings
+}
+
+// Fork switches the active leaf to messageID, moving the conversation onto
+// whichever branch that message belongs to.
+func (c *Chat) Fork(messageID string) error {
+	if messageID == "" {
+		c.ActiveLeafID = ""
+		return nil
+	}
+	if _, ok := c.MessagesByID[messageID]; !ok {
+		return errors.New("message not found: " + messageID)
+	}
+	c.ActiveLeafID = messageID
+	return nil
+}
+
+func (c *Chat) refreshTokenUsage() {
+	usage := 0
+	for _, m := range c.ActivePath() {
+		usage += m.GetQtdTokens()
+	}
+	c.TokenUsage = usage
+}
+
+func (c *Chat) End() {
+	c.Status = "ended"
+}
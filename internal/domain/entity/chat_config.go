@@ -0,0 +1,15 @@
+package entity
+
+// ChatConfig holds the completion parameters a Chat (or an Agent's defaults)
+// runs with.
+type ChatConfig struct {
+	Provider          string
+	Temperature       float32
+	TopP              float32
+	N                 int
+	Stop              []string
+	MaxTokens         int
+	PresencePenalty   float32
+	FrequencyePenalty float32
+	Model             *Model
+}
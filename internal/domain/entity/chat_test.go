@@ -0,0 +1,83 @@
+package entity
+
+import "testing"
+
+func newTestChat(t *testing.T) *Chat {
+	t.Helper()
+	model := NewModel("gpt-4o-mini", 0)
+	config := &ChatConfig{Model: model}
+	sys, err := NewMessage("system", "you are a helpful assistant", model)
+	if err != nil {
+		t.Fatalf("unexpected error creating system message: %v", err)
+	}
+	chat, err := NewChat("user-1", sys, config)
+	if err != nil {
+		t.Fatalf("unexpected error creating chat: %v", err)
+	}
+	return chat
+}
+
+func TestChatActivePathFollowsEdits(t *testing.T) {
+	chat := newTestChat(t)
+	model := chat.Config.Model
+
+	first, _ := NewMessage("user", "hello", model)
+	if err := chat.AddMessage(first); err != nil {
+		t.Fatalf("unexpected error adding message: %v", err)
+	}
+	reply, _ := NewMessage("assistant", "hi there", model)
+	if err := chat.AddMessage(reply); err != nil {
+		t.Fatalf("unexpected error adding message: %v", err)
+	}
+
+	path := chat.ActivePath()
+	if len(path) != 3 {
+		t.Fatalf("expected 3 messages on the active path, got %d", len(path))
+	}
+	if path[0].Role != "system" || path[1].ID != first.ID || path[2].ID != reply.ID {
+		t.Fatalf("active path is not root-first in edit order: %+v", path)
+	}
+
+	// Editing first: fork back to its parent and add a replacement message,
+	// the way EditMessageUseCase would.
+	if err := chat.Fork(first.ParentID); err != nil {
+		t.Fatalf("unexpected error forking: %v", err)
+	}
+	edited, _ := NewMessage("user", "hello again", model)
+	if err := chat.AddMessage(edited); err != nil {
+		t.Fatalf("unexpected error adding edited message: %v", err)
+	}
+
+	path = chat.ActivePath()
+	if len(path) != 2 {
+		t.Fatalf("expected the edit to drop the old reply from the active path, got %d messages", len(path))
+	}
+	if path[1].ID != edited.ID {
+		t.Fatalf("expected active leaf to be the edited message, got %q", path[1].ID)
+	}
+
+	siblings := chat.Siblings(first.ParentID)
+	if len(siblings) != 2 {
+		t.Fatalf("expected 2 siblings under the original parent, got %d", len(siblings))
+	}
+}
+
+func TestChatForkUnknownMessage(t *testing.T) {
+	chat := newTestChat(t)
+	if err := chat.Fork("does-not-exist"); err == nil {
+		t.Fatal("expected an error forking to an unknown message, got nil")
+	}
+}
+
+func TestChatForkEmptyClearsActiveLeaf(t *testing.T) {
+	chat := newTestChat(t)
+	if err := chat.Fork(""); err != nil {
+		t.Fatalf("unexpected error forking to empty: %v", err)
+	}
+	if chat.ActiveLeafID != "" {
+		t.Fatalf("expected ActiveLeafID to be cleared, got %q", chat.ActiveLeafID)
+	}
+	if len(chat.ActivePath()) != 0 {
+		t.Fatalf("expected an empty active path once there's no active leaf")
+	}
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// ToolCall is a single function/tool invocation the assistant asked for.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+type Message struct {
+	ID               string
+	ParentID         string
+	Role             string
+	Content          string
+	ToolCalls        []ToolCall
+	ToolCallID       string
+	Tokens           int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	LatencyMs        int64
+	Model            *Model
+	CreatedAt        time.Time
+}
+
+// NewMessage builds a Message for role, validating it before returning.
+func NewMessage(role, content string, model *Model) (*Message, error) {
+	msg := &Message{
+		ID:        uuid.New().String(),
+		Role:      role,
+		Content:   content,
+		Tokens:    CountTokens(content, model.GetModelName()),
+		Model:     model,
+		CreatedAt: time.Now(),
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewAssistantToolCallMessage builds the assistant message that carries the
+// model's tool calls instead of (or alongside) a direct text reply.
+func NewAssistantToolCallMessage(content string, toolCalls []ToolCall, model *Model) (*Message, error) {
+	msg := &Message{
+		ID:        uuid.New().String(),
+		Role:      "assistant",
+		Content:   content,
+		ToolCalls: toolCalls,
+		Tokens:    CountTokens(content, model.GetModelName()),
+		Model:     model,
+		CreatedAt: time.Now(),
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// NewToolMessage builds the "tool" role message fed back to the model with
+// the result of a tool call, keyed by the originating ToolCallID.
+func NewToolMessage(toolCallID, content string, model *Model) (*Message, error) {
+	msg := &Message{
+		ID:         uuid.New().String(),
+		Role:       "tool",
+		Content:    content,
+		ToolCallID: toolCallID,
+		Tokens:     CountTokens(content, model.GetModelName()),
+		Model:      model,
+		CreatedAt:  time.Now(),
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (m *Message) Validate() error {
+	switch m.Role {
+	case "user", "system", "assistant", "tool":
+	default:
+		return errors.New("invalid role: " + m.Role)
+	}
+	if m.Role == "tool" && m.ToolCallID == "" {
+		return errors.New("tool message requires a ToolCallID")
+	}
+	if m.Role != "tool" && m.Content == "" && len(m.ToolCalls) == 0 {
+		return errors.New("content cannot be empty")
+	}
+	return nil
+}
+
+func (m *Message) GetQtdTokens() int {
+	return m.Tokens
+}
+
+// CountTokens measures content the same way the target model will be
+// billed, falling back to a cheap whitespace approximation for models
+// tiktoken doesn't recognize.
+func CountTokens(content, modelName string) int {
+	encoding, err := tiktoken.EncodingForModel(modelName)
+	if err != nil {
+		return len(strings.Fields(content))
+	}
+	return len(encoding.Encode(content, nil, nil))
+}
@@ -0,0 +1,26 @@
+package entity
+
+// Model identifies the LLM a chat is bound to and the token budget it
+// enforces.
+type Model struct {
+	Name      string
+	MaxTokens int
+}
+
+// NewModel builds a Model for name with a budget of maxTokens, checked against
+// the estimated prompt (the chat's active path) before each completion; the
+// completion itself is not counted. A maxTokens of 0 leaves it unenforced.
+func NewModel(name string, maxTokens int) *Model {
+	return &Model{
+		Name:      name,
+		MaxTokens: maxTokens,
+	}
+}
+
+func (m *Model) GetModelName() string {
+	return m.Name
+}
+
+func (m *Model) GetMaxTokens() int {
+	return m.MaxTokens
+}
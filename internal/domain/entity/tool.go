@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"context"
+	"errors"
+)
+
+// Tool is a Go function the assistant can invoke mid-conversation. Parameters
+// is a JSON schema object describing the arguments, in the same shape OpenAI
+// (and compatible providers) expect for function/tool declarations.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+	Impl        func(ctx context.Context, args map[string]any) (string, error)
+}
+
+// Toolbox is a registry of tools keyed by name, looked up when the model
+// emits a tool call.
+type Toolbox struct {
+	tools map[string]Tool
+}
+
+func NewToolbox(tools ...Tool) *Toolbox {
+	tb := &Toolbox{tools: make(map[string]Tool, len(tools))}
+	for _, t := range tools {
+		tb.tools[t.Name] = t
+	}
+	return tb
+}
+
+func (tb *Toolbox) Register(tool Tool) {
+	tb.tools[tool.Name] = tool
+}
+
+func (tb *Toolbox) Get(name string) (Tool, bool) {
+	tool, ok := tb.tools[name]
+	return tool, ok
+}
+
+func (tb *Toolbox) List() []Tool {
+	tools := make([]Tool, 0, len(tb.tools))
+	for _, t := range tb.tools {
+		tools = append(tools, t)
+	}
+	return tools
+}
+
+// Invoke looks up name in the toolbox and runs it with args.
+func (tb *Toolbox) Invoke(ctx context.Context, name string, args map[string]any) (string, error) {
+	tool, ok := tb.Get(name)
+	if !ok {
+		return "", errors.New("unknown tool: " + name)
+	}
+	return tool.Impl(ctx, args)
+}
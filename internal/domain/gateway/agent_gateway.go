@@ -0,0 +1,17 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+)
+
+// AgentRepository persists Agent bundles so they can be resolved by name at
+// completion time.
+type AgentRepository interface {
+	CreateAgent(ctx context.Context, agent *entity.Agent) error
+	FindAgentByName(ctx context.Context, name string) (*entity.Agent, error)
+	ListAgents(ctx context.Context) ([]*entity.Agent, error)
+	UpdateAgent(ctx context.Context, agent *entity.Agent) error
+	DeleteAgent(ctx context.Context, name string) error
+}
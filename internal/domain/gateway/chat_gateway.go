@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+)
+
+// ChatGateway persists a Chat, including its full message tree (ParentID
+// links and the active-leaf pointer) so branches survive a save/load cycle.
+type ChatGateway interface {
+	CreateChat(ctx context.Context, chat *entity.Chat) error
+	FindChatByID(ctx context.Context, chatID string) (*entity.Chat, error)
+	SaveChat(ctx context.Context, chat *entity.Chat) error
+}
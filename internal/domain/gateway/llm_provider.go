@@ -0,0 +1,78 @@
+package gateway
+
+import "context"
+
+// ChatRequestMessage is a provider-agnostic chat message sent to an LLMProvider.
+type ChatRequestMessage struct {
+	Role       string
+	Content    string
+	ToolCallID string
+	ToolCalls  []ToolCall
+}
+
+// ToolDeclaration describes a callable tool in the JSON-schema shape
+// providers expect for function/tool-calling requests.
+type ToolDeclaration struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// ToolCall is a single tool invocation the model asked for, either complete
+// (non-streaming) or being assembled chunk by chunk while streaming.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// ChatRequest carries everything an LLMProvider needs to run a completion,
+// independent of which backend ends up serving it.
+type ChatRequest struct {
+	Model             string
+	Messages          []ChatRequestMessage
+	Temperature       float32
+	TopP              float32
+	N                 int
+	Stop              []string
+	MaxTokens         int
+	PresencePenalty   float32
+	FrequencyePenalty float32
+	Tools             []ToolDeclaration
+}
+
+// Usage is the provider-reported token accounting for a completion. A nil
+// *Usage on a Chunk means the provider doesn't report it, and the caller
+// should fall back to its own estimate.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Chunk is a single piece of a streamed completion. Done is set on the final
+// chunk, after which the channel is closed. ToolCalls is populated on Done
+// when the model decided to call tools instead of replying directly. Usage
+// is populated on Done by providers that report exact token counts. Err is
+// set on Done when the stream ended because of a transport failure rather
+// than the model finishing normally; callers must check it before treating a
+// Done chunk as a successful completion.
+type Chunk struct {
+	Delta     string
+	ToolCalls []ToolCall
+	Usage     *Usage
+	Done      bool
+	Err       error
+}
+
+// LLMProvider abstracts a streaming chat completion backend so the use case
+// layer does not depend on any single vendor's SDK or request/response types.
+type LLMProvider interface {
+	StreamCompletion(ctx context.Context, req ChatRequest) (<-chan Chunk, error)
+}
+
+// ProviderResolver looks up an LLMProvider by name, letting a chat be routed
+// to — and continued on — whichever backend its config names, instead of a
+// use case being pinned to a single provider for its whole lifetime.
+type ProviderResolver interface {
+	Provider(name string) (LLMProvider, error)
+}
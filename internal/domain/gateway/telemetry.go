@@ -0,0 +1,21 @@
+package gateway
+
+import "time"
+
+// CompletionTelemetry is a single observability sample for one completion
+// call, covering both token accounting and latency.
+type CompletionTelemetry struct {
+	ChatID           string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	TimeToFirstToken time.Duration
+	TotalLatency     time.Duration
+}
+
+// Telemetry publishes CompletionTelemetry samples. Implementations must be
+// safe to call from the use-case's hot path without blocking it.
+type Telemetry interface {
+	ObserveCompletion(sample CompletionTelemetry)
+}
@@ -0,0 +1,267 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+const (
+	anthropicAPIURL     = "https://api.anthropic.com/v1/messages"
+	anthropicAPIVersion = "2023-06-01"
+
+	// anthropicDefaultMaxTokens is the floor applied when a request leaves
+	// MaxTokens at 0. Anthropic (unlike OpenAI) rejects a request outright
+	// without a positive max_tokens, so "unset" can't be passed through as-is.
+	anthropicDefaultMaxTokens = 1024
+)
+
+// AnthropicProvider implements gateway.LLMProvider against the Anthropic
+// Messages API. Unlike OpenAI, Anthropic takes the system prompt as a
+// top-level field rather than a "system"-role message, and tool calls/results
+// are content blocks inside a message rather than dedicated roles, so the
+// role mapping happens here instead of at the use-case layer.
+type AnthropicProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewAnthropicProvider builds a provider from an Anthropic API key.
+func NewAnthropicProvider(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{apiKey: apiKey, httpClient: http.DefaultClient, baseURL: anthropicAPIURL}
+}
+
+// anthropicContentBlock covers the block shapes this provider produces or
+// consumes: "text" for plain content, "tool_use" for an assistant tool call,
+// and "tool_result" for the reply fed back to the model.
+type anthropicContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string                  `json:"role"`
+	Content []anthropicContentBlock `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema,omitempty"`
+}
+
+type anthropicRequest struct {
+	Model         string             `json:"model"`
+	System        string             `json:"system,omitempty"`
+	Messages      []anthropicMessage `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Temperature   float32            `json:"temperature,omitempty"`
+	TopP          float32            `json:"top_p,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool    `json:"tools,omitempty"`
+	Stream        bool               `json:"stream"`
+}
+
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Index   int    `json:"index"`
+	Message struct {
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	ContentBlock struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"content_block"`
+	Delta struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *AnthropicProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	body, err := json.Marshal(toAnthropicRequest(req))
+	if err != nil {
+		return nil, errors.New("error encoding anthropic request: " + err.Error())
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.New("error building anthropic request: " + err.Error())
+	}
+	httpReq.Header.Set("content-type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.New("error calling anthropic: " + err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("anthropic returned status %d", resp.StatusCode)
+	}
+
+	chunks := make(chan gateway.Chunk)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+		toolCalls := map[int]*gateway.ToolCall{}
+		order := []int{}
+		var usage *gateway.Usage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				continue
+			}
+			var chunk gateway.Chunk
+			switch event.Type {
+			case "message_start":
+				usage = &gateway.Usage{PromptTokens: event.Message.Usage.InputTokens}
+				continue
+			case "content_block_start":
+				if event.ContentBlock.Type == "tool_use" {
+					toolCalls[event.Index] = &gateway.ToolCall{ID: event.ContentBlock.ID, Name: event.ContentBlock.Name}
+					order = append(order, event.Index)
+				}
+				continue
+			case "content_block_delta":
+				switch event.Delta.Type {
+				case "text_delta":
+					chunk = gateway.Chunk{Delta: event.Delta.Text}
+				case "input_json_delta":
+					if tc, ok := toolCalls[event.Index]; ok {
+						tc.Arguments += event.Delta.PartialJSON
+					}
+					continue
+				default:
+					continue
+				}
+			case "message_delta":
+				if usage == nil {
+					usage = &gateway.Usage{}
+				}
+				usage.CompletionTokens = event.Usage.OutputTokens
+				continue
+			case "message_stop":
+				chunk = gateway.Chunk{Done: true, Usage: usage}
+				for _, idx := range order {
+					chunk.ToolCalls = append(chunk.ToolCalls, *toolCalls[idx])
+				}
+			case "error":
+				chunk = gateway.Chunk{Done: true, Err: errors.New("anthropic stream error: " + event.Error.Message)}
+			default:
+				continue
+			}
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return
+			}
+			if chunk.Done {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			select {
+			case chunks <- gateway.Chunk{Done: true, Err: errors.New("error streaming response: " + err.Error())}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// toAnthropicRequest maps the provider-agnostic ChatRequest onto Anthropic's
+// shape: the "system" role message becomes the top-level System field, "tool"
+// role messages become a user message carrying a tool_result block keyed by
+// ToolCallID, and assistant tool calls become tool_use blocks alongside any
+// text the model produced before calling them.
+func toAnthropicRequest(req gateway.ChatRequest) anthropicRequest {
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = anthropicDefaultMaxTokens
+	}
+	out := anthropicRequest{
+		Model:         req.Model,
+		MaxTokens:     maxTokens,
+		Temperature:   req.Temperature,
+		TopP:          req.TopP,
+		StopSequences: req.Stop,
+		Stream:        true,
+	}
+	for _, t := range req.Tools {
+		out.Tools = append(out.Tools, anthropicTool{
+			Name:        t.Name,
+			Description: t.Description,
+			InputSchema: t.Parameters,
+		})
+	}
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system":
+			out.System = msg.Content
+		case "tool":
+			out.Messages = append(out.Messages, anthropicMessage{
+				Role: "user",
+				Content: []anthropicContentBlock{{
+					Type:      "tool_result",
+					ToolUseID: msg.ToolCallID,
+					Content:   msg.Content,
+				}},
+			})
+		default:
+			var blocks []anthropicContentBlock
+			if msg.Content != "" {
+				blocks = append(blocks, anthropicContentBlock{Type: "text", Text: msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				blocks = append(blocks, anthropicContentBlock{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.Name,
+					Input: anthropicToolInput(call.Arguments),
+				})
+			}
+			out.Messages = append(out.Messages, anthropicMessage{Role: msg.Role, Content: blocks})
+		}
+	}
+	return out
+}
+
+// anthropicToolInput returns arguments as a JSON object, falling back to an
+// empty object for tool calls with no arguments.
+func anthropicToolInput(arguments string) json.RawMessage {
+	if arguments == "" {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(arguments)
+}
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+func TestToAnthropicRequestMapsToolRoundTrip(t *testing.T) {
+	req := gateway.ChatRequest{
+		Model: "claude-3-5-sonnet-20241022",
+		Tools: []gateway.ToolDeclaration{
+			{Name: "get_weather", Description: "looks up the weather", Parameters: map[string]any{"type": "object"}},
+		},
+		Messages: []gateway.ChatRequestMessage{
+			{Role: "system", Content: "you are a helpful assistant"},
+			{Role: "user", Content: "what's the weather in paris?"},
+			{Role: "assistant", ToolCalls: []gateway.ToolCall{{ID: "toolu_1", Name: "get_weather", Arguments: `{"city":"paris"}`}}},
+			{Role: "tool", ToolCallID: "toolu_1", Content: "18C and sunny"},
+		},
+	}
+
+	out := toAnthropicRequest(req)
+
+	if out.System != "you are a helpful assistant" {
+		t.Fatalf("expected the system message to be pulled out to the top-level field, got %q", out.System)
+	}
+	if len(out.Tools) != 1 || out.Tools[0].Name != "get_weather" {
+		t.Fatalf("expected req.Tools to be declared on the request, got %+v", out.Tools)
+	}
+	if len(out.Messages) != 3 {
+		t.Fatalf("expected system to be excluded from Messages, got %d entries", len(out.Messages))
+	}
+
+	assistantMsg := out.Messages[1]
+	if assistantMsg.Role != "assistant" {
+		t.Fatalf("expected the tool-call message to stay on the assistant role, got %q", assistantMsg.Role)
+	}
+	if len(assistantMsg.Content) != 1 || assistantMsg.Content[0].Type != "tool_use" {
+		t.Fatalf("expected a single tool_use block, got %+v", assistantMsg.Content)
+	}
+	if assistantMsg.Content[0].ID != "toolu_1" || assistantMsg.Content[0].Name != "get_weather" {
+		t.Fatalf("tool_use block doesn't carry the original call's id/name: %+v", assistantMsg.Content[0])
+	}
+	var input map[string]string
+	if err := json.Unmarshal(assistantMsg.Content[0].Input, &input); err != nil || input["city"] != "paris" {
+		t.Fatalf("expected the tool call arguments to round-trip as the tool_use input, got %q, err=%v", assistantMsg.Content[0].Input, err)
+	}
+
+	toolMsg := out.Messages[2]
+	if toolMsg.Role != "user" {
+		t.Fatalf("expected the tool result to be reframed as a user message (Anthropic has no \"tool\" role), got %q", toolMsg.Role)
+	}
+	if len(toolMsg.Content) != 1 || toolMsg.Content[0].Type != "tool_result" {
+		t.Fatalf("expected a single tool_result block, got %+v", toolMsg.Content)
+	}
+	if toolMsg.Content[0].ToolUseID != "toolu_1" || toolMsg.Content[0].Content != "18C and sunny" {
+		t.Fatalf("tool_result block doesn't carry the call id/result: %+v", toolMsg.Content[0])
+	}
+}
+
+func TestToAnthropicRequestEmptyToolArgumentsBecomeEmptyObject(t *testing.T) {
+	req := gateway.ChatRequest{
+		Messages: []gateway.ChatRequestMessage{
+			{Role: "assistant", ToolCalls: []gateway.ToolCall{{ID: "toolu_1", Name: "ping"}}},
+		},
+	}
+
+	out := toAnthropicRequest(req)
+
+	if string(out.Messages[0].Content[0].Input) != "{}" {
+		t.Fatalf("expected empty arguments to become an empty JSON object, got %q", out.Messages[0].Content[0].Input)
+	}
+}
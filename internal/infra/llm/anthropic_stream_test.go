@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+const anthropicSSEFixture = `data: {"type":"message_start","message":{"usage":{"input_tokens":42}}}
+
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text"}}
+
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"hi"}}
+
+data: {"type":"content_block_stop","index":0}
+
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":7}}
+
+data: {"type":"message_stop"}
+
+`
+
+func TestAnthropicStreamCompletionReportsUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		w.Write([]byte(anthropicSSEFixture))
+	}))
+	defer server.Close()
+
+	provider := &AnthropicProvider{apiKey: "test", httpClient: server.Client(), baseURL: server.URL}
+
+	chunks, err := provider.StreamCompletion(context.Background(), gateway.ChatRequest{Model: "claude-3-5-sonnet-20241022"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var delta string
+	var done gateway.Chunk
+	for chunk := range chunks {
+		if chunk.Done {
+			done = chunk
+			break
+		}
+		delta += chunk.Delta
+	}
+
+	if delta != "hi" {
+		t.Fatalf("expected the text delta to come through, got %q", delta)
+	}
+	if done.Usage == nil {
+		t.Fatal("expected the Done chunk to carry Usage, got nil")
+	}
+	if done.Usage.PromptTokens != 42 {
+		t.Fatalf("expected PromptTokens from message_start.usage.input_tokens, got %d", done.Usage.PromptTokens)
+	}
+	if done.Usage.CompletionTokens != 7 {
+		t.Fatalf("expected CompletionTokens from message_delta.usage.output_tokens, got %d", done.Usage.CompletionTokens)
+	}
+}
+
+func TestAnthropicStreamCompletionSurfacesErrorEvent(t *testing.T) {
+	const errorSSE = `data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		w.Write([]byte(errorSSE))
+	}))
+	defer server.Close()
+
+	provider := &AnthropicProvider{apiKey: "test", httpClient: server.Client(), baseURL: server.URL}
+
+	chunks, err := provider.StreamCompletion(context.Background(), gateway.ChatRequest{Model: "claude-3-5-sonnet-20241022"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var done gateway.Chunk
+	for chunk := range chunks {
+		if chunk.Done {
+			done = chunk
+		}
+	}
+	if done.Err == nil {
+		t.Fatal("expected an \"error\" SSE event to surface as an error on the Done chunk instead of a bare channel close")
+	}
+}
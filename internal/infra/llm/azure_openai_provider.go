@@ -0,0 +1,33 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/sashabaranov/go-openai"
+)
+
+// AzureOpenAIProvider implements gateway.LLMProvider against an Azure OpenAI
+// resource, where the model name is routed to a deployment name instead of
+// being sent as-is.
+type AzureOpenAIProvider struct {
+	client *openai.Client
+}
+
+// NewAzureOpenAIProvider builds a provider for an Azure OpenAI resource.
+// deployments maps a model name (e.g. "gpt-4o") to the Azure deployment name
+// configured for that resource.
+func NewAzureOpenAIProvider(apiKey, baseURL string, deployments map[string]string) *AzureOpenAIProvider {
+	config := openai.DefaultAzureConfig(apiKey, baseURL)
+	config.AzureModelMapperFunc = func(model string) string {
+		if deployment, ok := deployments[model]; ok {
+			return deployment
+		}
+		return model
+	}
+	return &AzureOpenAIProvider{client: openai.NewClientWithConfig(config)}
+}
+
+func (p *AzureOpenAIProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	return streamFromClient(ctx, p.client, req)
+}
@@ -0,0 +1,27 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAICompatibleProvider implements gateway.LLMProvider against any backend
+// that speaks the OpenAI chat completions API over a custom base URL, such as
+// Ollama or LM Studio.
+type OpenAICompatibleProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAICompatibleProvider builds a provider pointed at baseURL. apiKey
+// may be empty for backends that don't require authentication.
+func NewOpenAICompatibleProvider(baseURL, apiKey string) *OpenAICompatibleProvider {
+	config := openai.DefaultConfig(apiKey)
+	config.BaseURL = baseURL
+	return &OpenAICompatibleProvider{client: openai.NewClientWithConfig(config)}
+}
+
+func (p *OpenAICompatibleProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	return streamFromClient(ctx, p.client, req)
+}
@@ -0,0 +1,155 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIProvider implements gateway.LLMProvider against the public OpenAI API.
+type OpenAIProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAIProvider builds a provider from an API key, talking to the
+// standard OpenAI endpoint.
+func NewOpenAIProvider(apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{client: openai.NewClient(apiKey)}
+}
+
+// NewOpenAIProviderFromClient wraps an already configured *openai.Client,
+// letting callers share one client across providers (e.g. tests).
+func NewOpenAIProviderFromClient(client *openai.Client) *OpenAIProvider {
+	return &OpenAIProvider{client: client}
+}
+
+func (p *OpenAIProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	return streamFromClient(ctx, p.client, req)
+}
+
+func streamFromClient(ctx context.Context, client *openai.Client, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(req.Messages))
+	for _, msg := range req.Messages {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallID: msg.ToolCallID,
+			ToolCalls:  toOpenAIToolCalls(msg.ToolCalls),
+		})
+	}
+
+	tools := make([]openai.Tool, 0, len(req.Tools))
+	for _, t := range req.Tools {
+		tools = append(tools, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		})
+	}
+
+	resp, err := client.CreateChatCompletionStream(
+		ctx,
+		openai.ChatCompletionRequest{
+			Model:            req.Model,
+			Messages:         messages,
+			MaxTokens:        req.MaxTokens,
+			Temperature:      req.Temperature,
+			TopP:             req.TopP,
+			N:                req.N,
+			Stop:             req.Stop,
+			PresencePenalty:  req.PresencePenalty,
+			FrequencyPenalty: req.FrequencyePenalty,
+			Tools:            tools,
+			Stream:           true,
+		},
+	)
+	if err != nil {
+		return nil, errors.New("error creating chat completion: " + err.Error())
+	}
+
+	chunks := make(chan gateway.Chunk)
+	go func() {
+		defer close(chunks)
+		defer resp.Close()
+		toolCalls := map[int]*gateway.ToolCall{}
+		order := []int{}
+		for {
+			response, err := resp.Recv()
+			if errors.Is(err, io.EOF) {
+				done := gateway.Chunk{Done: true}
+				for _, idx := range order {
+					done.ToolCalls = append(done.ToolCalls, *toolCalls[idx])
+				}
+				select {
+				case chunks <- done:
+				case <-ctx.Done():
+				}
+				return
+			}
+			if err != nil {
+				select {
+				case chunks <- gateway.Chunk{Done: true, Err: errors.New("error streaming response: " + err.Error())}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			delta := response.Choices[0].Delta
+			for _, tc := range delta.ToolCalls {
+				idx := 0
+				if tc.Index != nil {
+					idx = *tc.Index
+				}
+				existing, ok := toolCalls[idx]
+				if !ok {
+					existing = &gateway.ToolCall{}
+					toolCalls[idx] = existing
+					order = append(order, idx)
+				}
+				if tc.ID != "" {
+					existing.ID = tc.ID
+				}
+				if tc.Function.Name != "" {
+					existing.Name = tc.Function.Name
+				}
+				existing.Arguments += tc.Function.Arguments
+			}
+			if delta.Content == "" {
+				continue
+			}
+			select {
+			case chunks <- gateway.Chunk{Delta: delta.Content}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// toOpenAIToolCalls maps an assistant message's tool calls onto the
+// ChatCompletionMessage.ToolCalls the API expects to find declared on the
+// assistant turn that precedes each "tool" role reply; without it, the API
+// rejects the tool message outright.
+func toOpenAIToolCalls(calls []gateway.ToolCall) []openai.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	out := make([]openai.ToolCall, 0, len(calls))
+	for _, c := range calls {
+		out = append(out, openai.ToolCall{
+			ID:   c.ID,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      c.Name,
+				Arguments: c.Arguments,
+			},
+		})
+	}
+	return out
+}
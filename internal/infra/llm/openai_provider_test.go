@@ -0,0 +1,82 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestStreamFromClientCarriesToolCallsOnReplay(t *testing.T) {
+	var captured openai.ChatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("content-type", "text/event-stream")
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"done\"}}]}\n\ndata: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	config := openai.DefaultConfig("test")
+	config.BaseURL = server.URL
+	client := openai.NewClientWithConfig(config)
+
+	req := gateway.ChatRequest{
+		Model: "gpt-4o-mini",
+		Messages: []gateway.ChatRequestMessage{
+			{Role: "user", Content: "what's the weather in paris?"},
+			{Role: "assistant", ToolCalls: []gateway.ToolCall{{ID: "call_1", Name: "get_weather", Arguments: `{"city":"paris"}`}}},
+			{Role: "tool", ToolCallID: "call_1", Content: "18C and sunny"},
+		},
+	}
+
+	chunks, err := streamFromClient(context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range chunks {
+	}
+
+	if len(captured.Messages) != 3 {
+		t.Fatalf("expected 3 messages on the wire, got %d", len(captured.Messages))
+	}
+	assistantMsg := captured.Messages[1]
+	if len(assistantMsg.ToolCalls) != 1 {
+		t.Fatalf("expected the assistant message to declare its tool call, got %+v", assistantMsg.ToolCalls)
+	}
+	if assistantMsg.ToolCalls[0].ID != "call_1" || assistantMsg.ToolCalls[0].Function.Name != "get_weather" {
+		t.Fatalf("tool call didn't round-trip onto the wire: %+v", assistantMsg.ToolCalls[0])
+	}
+}
+
+func TestStreamFromClientSurfacesTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		w.Write([]byte("data: {not valid json\n\n"))
+	}))
+	defer server.Close()
+
+	config := openai.DefaultConfig("test")
+	config.BaseURL = server.URL
+	client := openai.NewClientWithConfig(config)
+
+	chunks, err := streamFromClient(context.Background(), client, gateway.ChatRequest{Model: "gpt-4o-mini"})
+	if err != nil {
+		t.Fatalf("unexpected error opening the stream: %v", err)
+	}
+
+	var done gateway.Chunk
+	for chunk := range chunks {
+		if chunk.Done {
+			done = chunk
+		}
+	}
+	if done.Err == nil {
+		t.Fatal("expected a transport failure mid-stream to surface as an error on the Done chunk instead of a bare channel close")
+	}
+}
@@ -0,0 +1,37 @@
+package llm
+
+import (
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+// Provider name constants for the registry keys the concrete providers in
+// this package are conventionally registered under.
+const (
+	ProviderOpenAI           = "openai"
+	ProviderAzureOpenAI      = "azure-openai"
+	ProviderAnthropic        = "anthropic"
+	ProviderOpenAICompatible = "openai-compatible"
+)
+
+// ProviderRegistry is a gateway.ProviderResolver backed by a fixed name ->
+// LLMProvider map, built once at startup from whichever backends are
+// configured.
+type ProviderRegistry struct {
+	providers map[string]gateway.LLMProvider
+}
+
+// NewProviderRegistry builds a registry from providers, keyed by whatever
+// name callers will later resolve it by (see the Provider* constants above).
+func NewProviderRegistry(providers map[string]gateway.LLMProvider) *ProviderRegistry {
+	return &ProviderRegistry{providers: providers}
+}
+
+func (r *ProviderRegistry) Provider(name string) (gateway.LLMProvider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, errors.New("unknown provider: " + name)
+	}
+	return provider, nil
+}
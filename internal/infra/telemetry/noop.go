@@ -0,0 +1,13 @@
+package telemetry
+
+import "github.com/luanlsr/chatservice/internal/domain/gateway"
+
+// NoopTelemetry discards every sample. It's the default so wiring a real
+// backend in is opt-in.
+type NoopTelemetry struct{}
+
+func NewNoopTelemetry() *NoopTelemetry {
+	return &NoopTelemetry{}
+}
+
+func (t *NoopTelemetry) ObserveCompletion(sample gateway.CompletionTelemetry) {}
@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusTelemetry publishes completion telemetry as Prometheus metrics,
+// labeled by model so per-model dashboards and alerts are straightforward.
+type PrometheusTelemetry struct {
+	promptTokens     *prometheus.CounterVec
+	completionTokens *prometheus.CounterVec
+	totalTokens      *prometheus.CounterVec
+	timeToFirstToken *prometheus.HistogramVec
+	totalLatency     *prometheus.HistogramVec
+}
+
+func NewPrometheusTelemetry(registerer prometheus.Registerer) *PrometheusTelemetry {
+	t := &PrometheusTelemetry{
+		promptTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chatservice_completion_prompt_tokens_total",
+			Help: "Total prompt tokens sent to the LLM provider.",
+		}, []string{"model"}),
+		completionTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chatservice_completion_completion_tokens_total",
+			Help: "Total completion tokens received from the LLM provider.",
+		}, []string{"model"}),
+		totalTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chatservice_completion_total_tokens_total",
+			Help: "Total tokens (prompt + completion) across completions.",
+		}, []string{"model"}),
+		timeToFirstToken: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "chatservice_completion_time_to_first_token_seconds",
+			Help: "Latency between request start and the first streamed token.",
+		}, []string{"model"}),
+		totalLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "chatservice_completion_total_latency_seconds",
+			Help: "End-to-end latency of a completion call.",
+		}, []string{"model"}),
+	}
+	registerer.MustRegister(t.promptTokens, t.completionTokens, t.totalTokens, t.timeToFirstToken, t.totalLatency)
+	return t
+}
+
+func (t *PrometheusTelemetry) ObserveCompletion(sample gateway.CompletionTelemetry) {
+	t.promptTokens.WithLabelValues(sample.Model).Add(float64(sample.PromptTokens))
+	t.completionTokens.WithLabelValues(sample.Model).Add(float64(sample.CompletionTokens))
+	t.totalTokens.WithLabelValues(sample.Model).Add(float64(sample.TotalTokens))
+	t.timeToFirstToken.WithLabelValues(sample.Model).Observe(sample.TimeToFirstToken.Seconds())
+	t.totalLatency.WithLabelValues(sample.Model).Observe(sample.TotalLatency.Seconds())
+}
@@ -0,0 +1,83 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+)
+
+// maxDirTreeEntries caps how many paths are returned, for the same reason
+// http_get caps its response body (maxHTTPGetBody): keep a single tool result
+// within the token budget.
+const maxDirTreeEntries = 500
+
+// NewDirTree builds the dir_tree tool, confined to allowedRoot. It is never
+// registered by default: unrestricted, it would let the model enumerate any
+// path reachable by the server process, so whoever wires an agent's toolbox
+// must opt in explicitly and pick a root the agent is actually meant to
+// browse. Every "path" argument is required to resolve inside allowedRoot;
+// anything that escapes it (via "..", a symlink, or an absolute path
+// elsewhere) is refused.
+func NewDirTree(allowedRoot string) entity.Tool {
+	root := allowedRoot
+	if abs, err := filepath.Abs(allowedRoot); err == nil {
+		root = abs
+	}
+	return entity.Tool{
+		Name:        "dir_tree",
+		Description: "Lists files and directories under the given path, recursively.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The root directory to list.",
+				},
+			},
+			"required": []string{"path"},
+		},
+		Impl: func(ctx context.Context, args map[string]any) (string, error) {
+			return dirTreeImpl(ctx, root, args)
+		},
+	}
+}
+
+func dirTreeImpl(ctx context.Context, allowedRoot string, args map[string]any) (string, error) {
+	requested, _ := args["path"].(string)
+	if requested == "" {
+		return "", errors.New("dir_tree: missing required argument \"path\"")
+	}
+
+	root, err := filepath.Abs(requested)
+	if err != nil {
+		return "", errors.New("dir_tree: invalid path: " + err.Error())
+	}
+	if root != allowedRoot && !strings.HasPrefix(root, allowedRoot+string(filepath.Separator)) {
+		return "", errors.New("dir_tree: path escapes the configured root")
+	}
+
+	var entries []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if len(entries) >= maxDirTreeEntries {
+			return filepath.SkipAll
+		}
+		if path != root {
+			entries = append(entries, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(entries, "\n"), nil
+}
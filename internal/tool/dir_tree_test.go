@@ -0,0 +1,37 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirTreeRefusesPathOutsideAllowedRoot(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	tool := NewDirTree(root)
+
+	_, err := tool.Impl(context.Background(), map[string]any{"path": outside})
+	if err == nil {
+		t.Fatal("expected a path outside the configured root to be refused")
+	}
+}
+
+func TestDirTreeListsWithinAllowedRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "file.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatalf("failed to set up fixture: %v", err)
+	}
+
+	tool := NewDirTree(root)
+
+	out, err := tool.Impl(context.Background(), map[string]any{"path": root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out == "" {
+		t.Fatal("expected the listing to include the fixture file")
+	}
+}
@@ -0,0 +1,107 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+)
+
+// maxHTTPGetBody caps how much of a response body is fed back to the model,
+// so a tool call against a large document doesn't blow the token budget.
+const maxHTTPGetBody = 8192
+
+// NewHTTPGet builds the http_get tool. It is never registered by default:
+// http_get lets the model make arbitrary outbound HTTP requests on the
+// server's behalf, so an agent should only be granted it after a deliberate,
+// explicit opt-in by whoever wires that agent's toolbox. Even then, requests
+// to loopback, link-local (which covers the 169.254.169.254 cloud metadata
+// endpoint), and private address ranges are refused, to keep a prompt
+// injection from turning this tool into an SSRF primitive against internal
+// infrastructure.
+func NewHTTPGet() entity.Tool {
+	return entity.Tool{
+		Name:        "http_get",
+		Description: "Fetches the body of a URL over HTTP GET and returns it as text.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"url": map[string]any{
+					"type":        "string",
+					"description": "The URL to fetch.",
+				},
+			},
+			"required": []string{"url"},
+		},
+		Impl: httpGetImpl,
+	}
+}
+
+func httpGetImpl(ctx context.Context, args map[string]any) (string, error) {
+	rawURL, _ := args["url"].(string)
+	if rawURL == "" {
+		return "", errors.New("http_get: missing required argument \"url\"")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.New("http_get: invalid url: " + err.Error())
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", errors.New("http_get: only http and https urls are allowed")
+	}
+	if err := guardAgainstPrivateHost(ctx, parsed.Hostname()); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{
+		CheckRedirect: func(next *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("http_get: stopped after 10 redirects")
+			}
+			if next.URL.Scheme != "http" && next.URL.Scheme != "https" {
+				return errors.New("http_get: only http and https urls are allowed")
+			}
+			return guardAgainstPrivateHost(next.Context(), next.URL.Hostname())
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPGetBody))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// guardAgainstPrivateHost resolves host and rejects it if any of its
+// addresses are loopback, link-local, or private, so a DNS name can't be
+// used to route around a plain IP-literal check.
+func guardAgainstPrivateHost(ctx context.Context, host string) error {
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.New("http_get: could not resolve host: " + err.Error())
+	}
+	for _, ip := range ips {
+		if isDisallowedAddr(ip.IP) {
+			return errors.New("http_get: refusing to fetch a loopback, link-local, or private address")
+		}
+	}
+	return nil
+}
+
+func isDisallowedAddr(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified()
+}
@@ -0,0 +1,33 @@
+package tool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHTTPGetRefusesLoopbackAddress(t *testing.T) {
+	tool := NewHTTPGet()
+
+	_, err := tool.Impl(context.Background(), map[string]any{"url": "http://127.0.0.1:80/"})
+	if err == nil {
+		t.Fatal("expected a loopback address to be refused")
+	}
+}
+
+func TestHTTPGetRefusesCloudMetadataAddress(t *testing.T) {
+	tool := NewHTTPGet()
+
+	_, err := tool.Impl(context.Background(), map[string]any{"url": "http://169.254.169.254/latest/meta-data/"})
+	if err == nil {
+		t.Fatal("expected the link-local cloud metadata address to be refused")
+	}
+}
+
+func TestHTTPGetRefusesNonHTTPScheme(t *testing.T) {
+	tool := NewHTTPGet()
+
+	_, err := tool.Impl(context.Background(), map[string]any{"url": "file:///etc/passwd"})
+	if err == nil {
+		t.Fatal("expected a non-http(s) scheme to be refused")
+	}
+}
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type CreateAgentInputDTO struct {
+	Name          string
+	SystemPrompt  string
+	AllowedTools  []string
+	DefaultConfig entity.ChatConfig
+}
+
+type AgentOutputDTO struct {
+	Name         string
+	SystemPrompt string
+	AllowedTools []string
+}
+
+type CreateAgentUseCase struct {
+	AgentRepository gateway.AgentRepository
+}
+
+func newCreateAgentUseCase(agentRepository gateway.AgentRepository) *CreateAgentUseCase {
+	return &CreateAgentUseCase{AgentRepository: agentRepository}
+}
+
+func (uc *CreateAgentUseCase) Execute(ctx context.Context, input CreateAgentInputDTO) (*AgentOutputDTO, error) {
+	agent, err := entity.NewAgent(input.Name, input.SystemPrompt, input.AllowedTools, input.DefaultConfig)
+	if err != nil {
+		return nil, errors.New("error creating agent: " + err.Error())
+	}
+	if err := uc.AgentRepository.CreateAgent(ctx, agent); err != nil {
+		return nil, errors.New("error persisting agent: " + err.Error())
+	}
+	return &AgentOutputDTO{
+		Name:         agent.Name,
+		SystemPrompt: agent.SystemPrompt,
+		AllowedTools: agent.AllowedTools,
+	}, nil
+}
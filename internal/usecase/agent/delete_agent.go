@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type DeleteAgentInputDTO struct {
+	Name string
+}
+
+type DeleteAgentUseCase struct {
+	AgentRepository gateway.AgentRepository
+}
+
+func newDeleteAgentUseCase(agentRepository gateway.AgentRepository) *DeleteAgentUseCase {
+	return &DeleteAgentUseCase{AgentRepository: agentRepository}
+}
+
+func (uc *DeleteAgentUseCase) Execute(ctx context.Context, input DeleteAgentInputDTO) error {
+	if err := uc.AgentRepository.DeleteAgent(ctx, input.Name); err != nil {
+		return errors.New("error deleting agent: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type FindAgentInputDTO struct {
+	Name string
+}
+
+type FindAgentUseCase struct {
+	AgentRepository gateway.AgentRepository
+}
+
+func newFindAgentUseCase(agentRepository gateway.AgentRepository) *FindAgentUseCase {
+	return &FindAgentUseCase{AgentRepository: agentRepository}
+}
+
+func (uc *FindAgentUseCase) Execute(ctx context.Context, input FindAgentInputDTO) (*AgentOutputDTO, error) {
+	agent, err := uc.AgentRepository.FindAgentByName(ctx, input.Name)
+	if err != nil {
+		return nil, errors.New("error finding agent: " + err.Error())
+	}
+	return &AgentOutputDTO{
+		Name:         agent.Name,
+		SystemPrompt: agent.SystemPrompt,
+		AllowedTools: agent.AllowedTools,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type ListAgentsUseCase struct {
+	AgentRepository gateway.AgentRepository
+}
+
+func newListAgentsUseCase(agentRepository gateway.AgentRepository) *ListAgentsUseCase {
+	return &ListAgentsUseCase{AgentRepository: agentRepository}
+}
+
+func (uc *ListAgentsUseCase) Execute(ctx context.Context) ([]AgentOutputDTO, error) {
+	agents, err := uc.AgentRepository.ListAgents(ctx)
+	if err != nil {
+		return nil, errors.New("error listing agents: " + err.Error())
+	}
+	output := make([]AgentOutputDTO, 0, len(agents))
+	for _, agent := range agents {
+		output = append(output, AgentOutputDTO{
+			Name:         agent.Name,
+			SystemPrompt: agent.SystemPrompt,
+			AllowedTools: agent.AllowedTools,
+		})
+	}
+	return output, nil
+}
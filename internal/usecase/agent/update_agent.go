@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type UpdateAgentInputDTO struct {
+	Name          string
+	SystemPrompt  string
+	AllowedTools  []string
+	DefaultConfig entity.ChatConfig
+}
+
+type UpdateAgentUseCase struct {
+	AgentRepository gateway.AgentRepository
+}
+
+func newUpdateAgentUseCase(agentRepository gateway.AgentRepository) *UpdateAgentUseCase {
+	return &UpdateAgentUseCase{AgentRepository: agentRepository}
+}
+
+func (uc *UpdateAgentUseCase) Execute(ctx context.Context, input UpdateAgentInputDTO) (*AgentOutputDTO, error) {
+	agent, err := uc.AgentRepository.FindAgentByName(ctx, input.Name)
+	if err != nil {
+		return nil, errors.New("error finding agent: " + err.Error())
+	}
+	agent.SystemPrompt = input.SystemPrompt
+	agent.AllowedTools = input.AllowedTools
+	agent.DefaultConfig = input.DefaultConfig
+	if err := agent.Validate(); err != nil {
+		return nil, errors.New("error validating agent: " + err.Error())
+	}
+	if err := uc.AgentRepository.UpdateAgent(ctx, agent); err != nil {
+		return nil, errors.New("error updating agent: " + err.Error())
+	}
+	return &AgentOutputDTO{
+		Name:         agent.Name,
+		SystemPrompt: agent.SystemPrompt,
+		AllowedTools: agent.AllowedTools,
+	}, nil
+}
@@ -2,16 +2,22 @@ package chatcomplitionstream
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"io"
 	"strings"
+	"time"
 
 	"github.com/luanlsr/chatservice/internal/domain/entity"
 	"github.com/luanlsr/chatservice/internal/domain/gateway"
-	"github.com/sashabaranov/go-openai"
+	"github.com/luanlsr/chatservice/internal/infra/telemetry"
 )
 
+// defaultMaxToolIterations bounds the agent loop when a request doesn't set
+// Config.MaxToolIterations, so a misbehaving tool/model pair can't loop forever.
+const defaultMaxToolIterations = 5
+
 type ChatCompletionConfigInputDTO struct {
+	Provider string
 	Model string
 	ModelMaxToken int
 	Temperature float32
@@ -22,12 +28,15 @@ type ChatCompletionConfigInputDTO struct {
 	PresencePenalty float32
 	FrequencyePenalty float32
 	InitialSystemMessage string
+	Tools []string
+	MaxToolIterations int
 }
 
 type ChatCompletionInputDTO struct{
 	ChatID string
 	UserID string
 	UserMessage string
+	AgentName string
 	Config ChatCompletionConfigInputDTO
 }
 
@@ -35,31 +44,61 @@ type ChatCompletionOutputDTO struct{
 	ChatID string
 	UserID string
 	Content string
+	Delta string
+	Done bool
 }
 
 type ChatCompletionUseCase struct {
 	ChatGateway gateway.ChatGateway
-	OpenAiClient *openai.Client
+	Providers gateway.ProviderResolver
+	DefaultProvider string
+	AgentRepository gateway.AgentRepository
+	Toolbox *entity.Toolbox
+	Telemetry gateway.Telemetry
 	Stream chan ChatCompletionOutputDTO
 }
 
-func newChatCompletionUseCase(chatGateway gateway.ChatGateway, openAiClient *openai.Client, stream chan ChatCompletionOutputDTO) (*ChatCompletionUseCase) {
+// newChatCompletionUseCase wires a use case against providers, a
+// gateway.ProviderResolver that a chat's (or request's) Config.Provider is
+// resolved against. defaultProvider is used when neither the request nor an
+// existing chat names one.
+func newChatCompletionUseCase(chatGateway gateway.ChatGateway, providers gateway.ProviderResolver, defaultProvider string, agentRepository gateway.AgentRepository, toolbox *entity.Toolbox, telem gateway.Telemetry, stream chan ChatCompletionOutputDTO) (*ChatCompletionUseCase) {
+	if telem == nil {
+		telem = telemetry.NewNoopTelemetry()
+	}
 	return &ChatCompletionUseCase{
 		ChatGateway: chatGateway,
-		OpenAiClient: openAiClient,
+		Providers: providers,
+		DefaultProvider: defaultProvider,
+		AgentRepository: agentRepository,
+		Toolbox: toolbox,
+		Telemetry: telem,
 		Stream: stream,
 	}
 }
 
 func (uc *ChatCompletionUseCase) Execute(ctx context.Context, input ChatCompletionInputDTO) (*ChatCompletionOutputDTO, error) {
+	if uc.Telemetry == nil {
+		uc.Telemetry = telemetry.NewNoopTelemetry()
+	}
+
+	agent, err := uc.resolveAgent(ctx, input.AgentName)
+	if err != nil {
+		return nil, err
+	}
+
 	chat, err  := uc.ChatGateway.FindChatByID(ctx, input.ChatID)
 	if err != nil {
 		if err.Error() == "chat not found" {
 			// create new chat (entity)
-			chat, err = createNewChat(input)
+			chat, err = createNewChat(input, agent, uc.DefaultProvider)
 			if err != nil {
 				return nil, errors.New("error creating new chat: " + err.Error())
 			}
+			// Key the new chat by the requested ChatID so later turns find it.
+			if input.ChatID != "" {
+				chat.ID = input.ChatID
+			}
 			// save on database***
 			err = uc.ChatGateway.CreateChat(ctx, chat)
 			if err != nil {
@@ -69,6 +108,22 @@ func (uc *ChatCompletionUseCase) Execute(ctx context.Context, input ChatCompleti
 			return nil, errors.New("error fetching existing chat: " + err.Error())
 		}
 	}
+
+	// A request can switch a chat onto a different provider mid-conversation;
+	// absent that, the chat keeps running on whichever provider it started on.
+	providerName := input.Config.Provider
+	if providerName == "" {
+		providerName = chat.Config.Provider
+	}
+	if providerName == "" {
+		providerName = uc.DefaultProvider
+	}
+	chat.Config.Provider = providerName
+	provider, err := uc.Providers.Provider(providerName)
+	if err != nil {
+		return nil, errors.New("error resolving provider: " + err.Error())
+	}
+
 	userMessage, err := entity.NewMessage("user", input.UserMessage, chat.Config.Model)
 	if err != nil {
 		return nil, errors.New("error creating user message: " + err.Error())
@@ -77,18 +132,122 @@ func (uc *ChatCompletionUseCase) Execute(ctx context.Context, input ChatCompleti
 	if err != nil {
 		return nil, errors.New("error adding new message: " + err.Error())
 	}
+	if maxTokens := chat.Config.Model.GetMaxTokens(); maxTokens > 0 && chat.TokenUsage > maxTokens {
+		return nil, errors.New("estimated prompt exceeds ModelMaxToken budget")
+	}
+	promptTokens := chat.TokenUsage
+
+	tools := uc.resolveTools(input.Config.Tools, agent)
+
+	maxToolIterations := input.Config.MaxToolIterations
+	if maxToolIterations <= 0 {
+		maxToolIterations = defaultMaxToolIterations
+	}
+
+	start := time.Now()
+	var firstTokenAt time.Time
+
+	var fullResponse strings.Builder
+	for iteration := 0; ; iteration++ {
+		fullResponse.Reset()
+		toolCalls, usage, err := uc.streamCompletion(ctx, provider, chat, tools, &fullResponse, &firstTokenAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(toolCalls) == 0 {
+			assistant, err := entity.NewMessage("assistant", fullResponse.String(), chat.Config.Model)
+			if err != nil {
+				return nil, errors.New("error creating assistant message: " + err.Error())
+			}
+			if usage != nil {
+				assistant.PromptTokens = usage.PromptTokens
+				assistant.CompletionTokens = usage.CompletionTokens
+				assistant.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+			} else {
+				assistant.PromptTokens = promptTokens
+				assistant.CompletionTokens = assistant.Tokens
+				assistant.TotalTokens = promptTokens + assistant.Tokens
+			}
+			assistant.LatencyMs = time.Since(start).Milliseconds()
+			if err := uc.appendAndSave(ctx, chat, assistant); err != nil {
+				return nil, err
+			}
+			uc.Telemetry.ObserveCompletion(gateway.CompletionTelemetry{
+				ChatID: chat.ID,
+				Model: chat.Config.Model.Name,
+				PromptTokens: assistant.PromptTokens,
+				CompletionTokens: assistant.CompletionTokens,
+				TotalTokens: assistant.TotalTokens,
+				TimeToFirstToken: firstTokenAt.Sub(start),
+				TotalLatency: time.Since(start),
+			})
+			break
+		}
+
+		if iteration >= maxToolIterations {
+			return nil, errors.New("tool call loop exceeded MaxToolIterations")
+		}
+
+		assistant, err := entity.NewAssistantToolCallMessage(fullResponse.String(), toEntityToolCalls(toolCalls), chat.Config.Model)
+		if err != nil {
+			return nil, errors.New("error creating assistant tool-call message: " + err.Error())
+		}
+		if err := uc.appendAndSave(ctx, chat, assistant); err != nil {
+			return nil, err
+		}
+
+		for _, call := range toolCalls {
+			result, err := uc.invokeTool(ctx, call, tools)
+			if err != nil {
+				result = "error: " + err.Error()
+			}
+			toolMessage, err := entity.NewToolMessage(call.ID, result, chat.Config.Model)
+			if err != nil {
+				return nil, errors.New("error creating tool message: " + err.Error())
+			}
+			if err := uc.appendAndSave(ctx, chat, toolMessage); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	select {
+	case uc.Stream <- ChatCompletionOutputDTO{
+		ChatID: chat.ID,
+		UserID: chat.UserID,
+		Content: fullResponse.String(),
+		Done: true,
+	}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &ChatCompletionOutputDTO{
+		ChatID: input.ChatID,
+		UserID: chat.UserID,
+		Content: fullResponse.String(),
+	}, nil
+}
 
-	messages := []openai.ChatCompletionMessage{}
-	for _, msg := range chat.Messages {
-		messages = append(messages, openai.ChatCompletionMessage{
+// streamCompletion runs a single completion call, forwarding content deltas
+// to uc.Stream and returning any tool calls the model asked for, plus the
+// provider-reported token usage when it reports one. firstTokenAt is
+// stamped the first time any delta arrives, across the whole Execute call.
+func (uc *ChatCompletionUseCase) streamCompletion(ctx context.Context, provider gateway.LLMProvider, chat *entity.Chat, tools []gateway.ToolDeclaration, fullResponse *strings.Builder, firstTokenAt *time.Time) ([]gateway.ToolCall, *gateway.Usage, error) {
+	messages := []gateway.ChatRequestMessage{}
+	for _, msg := range chat.ActivePath() {
+		messages = append(messages, gateway.ChatRequestMessage{
 			Role: msg.Role,
 			Content: msg.Content,
+			ToolCallID: msg.ToolCallID,
+			ToolCalls: toGatewayToolCalls(msg.ToolCalls),
 		})
 	}
 
-	resp, err := uc.OpenAiClient.CreateChatCompletionStream(
+	chunks, err := provider.StreamCompletion(
 		ctx,
-		openai.ChatCompletionRequest{
+		gateway.ChatRequest{
 			Model: chat.Config.Model.Name,
 			Messages: messages,
 			MaxTokens: chat.Config.MaxTokens,
@@ -96,67 +255,156 @@ func (uc *ChatCompletionUseCase) Execute(ctx context.Context, input ChatCompleti
 			TopP: chat.Config.TopP,
 			Stop: chat.Config.Stop,
 			PresencePenalty: chat.Config.PresencePenalty,
-			FrequencyPenalty: chat.Config.PresencePenalty,
-			Stream: false,
-		}, 
+			FrequencyePenalty: chat.Config.FrequencyePenalty,
+			Tools: tools,
+		},
 	)
 	if err != nil {
-		return nil, errors.New("error creating chat completion: " + err.Error())
+		return nil, nil, errors.New("error creating chat completion: " + err.Error())
 	}
 
-	var fullResponse strings.Builder
-
 	for {
-		response, err := resp.Recv()
-		if errors.Is(err, io.EOF){
-			break
+		chunk, ok := <-chunks
+		if !ok {
+			return nil, nil, nil
 		}
-		if err != nil {
-			return nil, errors.New("error straming response: " + err.Error())
+		if chunk.Done {
+			if chunk.Err != nil {
+				return nil, nil, chunk.Err
+			}
+			return chunk.ToolCalls, chunk.Usage, nil
+		}
+		if firstTokenAt.IsZero() && chunk.Delta != "" {
+			*firstTokenAt = time.Now()
 		}
-		fullResponse.WriteString((response.Choices[0].Delta.Content))
+		fullResponse.WriteString(chunk.Delta)
 		r := ChatCompletionOutputDTO{
 			ChatID: chat.ID,
 			UserID: chat.UserID,
-			Content: fullResponse.String(),
+			Delta: chunk.Delta,
+		}
+		select {
+		case uc.Stream <- r:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
 		}
-		uc.Stream <- r
 	}
+}
 
-	assistant, err := entity.NewMessage("assistant", fullResponse.String(), chat.Config.Model)
-	if err != nil {
-		return nil, errors.New("error creating assistant message: " + err.Error())
+func (uc *ChatCompletionUseCase) appendAndSave(ctx context.Context, chat *entity.Chat, message *entity.Message) error {
+	if err := chat.AddMessage(message); err != nil {
+		return errors.New("error adding new message: " + err.Error())
+	}
+	if err := uc.ChatGateway.SaveChat(ctx, chat); err != nil {
+		return errors.New("error saving chat: " + err.Error())
 	}
+	return nil
+}
 
-	err = chat.AddMessage(assistant)
-	if err != nil {
-		return nil, errors.New("error adding new mesage: " + err.Error())
+// invokeTool runs call only if it names one of the tools offered to the model
+// for this completion; any other tool name is refused.
+func (uc *ChatCompletionUseCase) invokeTool(ctx context.Context, call gateway.ToolCall, offered []gateway.ToolDeclaration) (string, error) {
+	allowed := false
+	for _, t := range offered {
+		if t.Name == call.Name {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", errors.New("tool not available: " + call.Name)
+	}
+	var args map[string]any
+	if call.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
+			return "", errors.New("error decoding tool arguments: " + err.Error())
+		}
+	}
+	return uc.Toolbox.Invoke(ctx, call.Name, args)
+}
+
+// resolveTools narrows uc.Toolbox down to the names requested for this
+// completion. Tools are only ever offered inside an explicit agent context:
+// without one, no tools are exposed to the model regardless of what the
+// request asks for.
+func (uc *ChatCompletionUseCase) resolveTools(names []string, agent *entity.Agent) []gateway.ToolDeclaration {
+	if uc.Toolbox == nil || agent == nil {
+		return nil
 	}
+	if len(names) == 0 {
+		names = agent.AllowedTools
+	}
+	tools := make([]gateway.ToolDeclaration, 0, len(names))
+	for _, name := range names {
+		if !agent.AllowsTool(name) {
+			continue
+		}
+		tool, ok := uc.Toolbox.Get(name)
+		if !ok {
+			continue
+		}
+		tools = append(tools, gateway.ToolDeclaration{
+			Name: tool.Name,
+			Description: tool.Description,
+			Parameters: tool.Parameters,
+		})
+	}
+	return tools
+}
 
-	err = uc.ChatGateway.SaveChat(ctx, chat)
+// resolveAgent looks up the agent for name, returning nil (not an error) when
+// name is empty so non-agent requests keep working unchanged.
+func (uc *ChatCompletionUseCase) resolveAgent(ctx context.Context, name string) (*entity.Agent, error) {
+	if name == "" {
+		return nil, nil
+	}
+	agent, err := uc.AgentRepository.FindAgentByName(ctx, name)
 	if err != nil {
-		return nil, errors.New("error saving chat: " + err.Error())
+		return nil, errors.New("error resolving agent: " + err.Error())
 	}
-	return &ChatCompletionOutputDTO{
-		ChatID: input.ChatID,
-		UserID: chat.UserID,
-		Content: fullResponse.String(),
-	}, nil
+	return agent, nil
+}
+
+func toEntityToolCalls(calls []gateway.ToolCall) []entity.ToolCall {
+	out := make([]entity.ToolCall, 0, len(calls))
+	for _, c := range calls {
+		out = append(out, entity.ToolCall{ID: c.ID, Name: c.Name, Arguments: c.Arguments})
+	}
+	return out
 }
 
-func createNewChat(input ChatCompletionInputDTO) (*entity.Chat, error) {
-	model := entity.NewModel(input.Config.Model, input.Config.ModelMaxToken)
+func toGatewayToolCalls(calls []entity.ToolCall) []gateway.ToolCall {
+	out := make([]gateway.ToolCall, 0, len(calls))
+	for _, c := range calls {
+		out = append(out, gateway.ToolCall{ID: c.ID, Name: c.Name, Arguments: c.Arguments})
+	}
+	return out
+}
+
+func createNewChat(input ChatCompletionInputDTO, agent *entity.Agent, defaultProvider string) (*entity.Chat, error) {
+	config := input.Config
+	systemMessage := config.InitialSystemMessage
+	if agent != nil {
+		config = mergeAgentConfig(agent.DefaultConfig, config)
+		systemMessage = agent.SystemPrompt
+	}
+	if config.Provider == "" {
+		config.Provider = defaultProvider
+	}
+
+	model := entity.NewModel(config.Model, config.ModelMaxToken)
 	chatConfig := &entity.ChatConfig {
-		Temperature: input.Config.Temperature,
-		TopP: input.Config.TopP,
-		N: input.Config.N,
-		Stop: input.Config.Stop,
-		MaxTokens: input.Config.MaxTokens,
-		PresencePenalty: input.Config.PresencePenalty,
-		FrequencyePenalty: input.Config.FrequencyePenalty,
+		Provider: config.Provider,
+		Temperature: config.Temperature,
+		TopP: config.TopP,
+		N: config.N,
+		Stop: config.Stop,
+		MaxTokens: config.MaxTokens,
+		PresencePenalty: config.PresencePenalty,
+		FrequencyePenalty: config.FrequencyePenalty,
 		Model: model,
 	}
-	initialMessage, err := entity.NewMessage("system", input.Config.InitialSystemMessage, model)
+	initialMessage, err := entity.NewMessage("system", systemMessage, model)
 	if err != nil {
 		return nil, errors.New("error creating initial message: " + err.Error())
 	}
@@ -165,4 +413,40 @@ func createNewChat(input ChatCompletionInputDTO) (*entity.Chat, error) {
 		return nil, errors.New("error creating new chat: " + err.Error())
 	}
 	return chat, nil
+}
+
+// mergeAgentConfig fills any zero-valued field of override with the agent's
+// default, so a request only needs to specify what it wants to change.
+func mergeAgentConfig(defaults entity.ChatConfig, override ChatCompletionConfigInputDTO) ChatCompletionConfigInputDTO {
+	if override.Provider == "" {
+		override.Provider = defaults.Provider
+	}
+	if override.Model == "" && defaults.Model != nil {
+		override.Model = defaults.Model.GetModelName()
+	}
+	if override.ModelMaxToken == 0 && defaults.Model != nil {
+		override.ModelMaxToken = defaults.Model.GetMaxTokens()
+	}
+	if override.Temperature == 0 {
+		override.Temperature = defaults.Temperature
+	}
+	if override.TopP == 0 {
+		override.TopP = defaults.TopP
+	}
+	if override.N == 0 {
+		override.N = defaults.N
+	}
+	if len(override.Stop) == 0 {
+		override.Stop = defaults.Stop
+	}
+	if override.MaxTokens == 0 {
+		override.MaxTokens = defaults.MaxTokens
+	}
+	if override.PresencePenalty == 0 {
+		override.PresencePenalty = defaults.PresencePenalty
+	}
+	if override.FrequencyePenalty == 0 {
+		override.FrequencyePenalty = defaults.FrequencyePenalty
+	}
+	return override
 }
\ No newline at end of file
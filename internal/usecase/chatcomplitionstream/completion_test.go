@@ -0,0 +1,119 @@
+package chatcomplitionstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+// stubChatGateway keeps chats in memory, behaving like a fresh store that's
+// never seen ChatID before until CreateChat is called.
+type stubChatGateway struct {
+	chats map[string]*entity.Chat
+}
+
+func newStubChatGateway() *stubChatGateway {
+	return &stubChatGateway{chats: make(map[string]*entity.Chat)}
+}
+
+func (s *stubChatGateway) CreateChat(ctx context.Context, chat *entity.Chat) error {
+	s.chats[chat.ID] = chat
+	return nil
+}
+
+func (s *stubChatGateway) FindChatByID(ctx context.Context, chatID string) (*entity.Chat, error) {
+	chat, ok := s.chats[chatID]
+	if !ok {
+		return nil, errors.New("chat not found")
+	}
+	return chat, nil
+}
+
+func (s *stubChatGateway) SaveChat(ctx context.Context, chat *entity.Chat) error {
+	s.chats[chat.ID] = chat
+	return nil
+}
+
+// stubLLMProvider replies with a single fixed assistant message and no tool
+// calls, closing the channel right after Done.
+type stubLLMProvider struct {
+	reply string
+}
+
+func (s *stubLLMProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	chunks := make(chan gateway.Chunk, 2)
+	chunks <- gateway.Chunk{Delta: s.reply}
+	chunks <- gateway.Chunk{Done: true}
+	close(chunks)
+	return chunks, nil
+}
+
+// singleProviderResolver is a gateway.ProviderResolver that always returns
+// the same provider, regardless of the name it's asked to resolve.
+type singleProviderResolver struct {
+	provider gateway.LLMProvider
+}
+
+func (r singleProviderResolver) Provider(name string) (gateway.LLMProvider, error) {
+	return r.provider, nil
+}
+
+func newTestUseCase(provider gateway.LLMProvider) *ChatCompletionUseCase {
+	return newChatCompletionUseCase(newStubChatGateway(), singleProviderResolver{provider}, "stub", nil, nil, nil, make(chan ChatCompletionOutputDTO, 8))
+}
+
+func TestExecuteDefaultsNilTelemetryWithoutPanicking(t *testing.T) {
+	uc := newTestUseCase(&stubLLMProvider{reply: "hi"})
+
+	_, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model: "gpt-4o-mini",
+			InitialSystemMessage: "you are a helpful assistant",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExecuteUnsetModelMaxTokenIsUnenforced(t *testing.T) {
+	uc := newTestUseCase(&stubLLMProvider{reply: "hi"})
+
+	_, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model: "gpt-4o-mini",
+			ModelMaxToken: 0,
+			InitialSystemMessage: "you are a helpful assistant",
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected an unset ModelMaxToken to leave the budget unenforced, got: %v", err)
+	}
+}
+
+func TestExecuteRejectsPromptOverModelMaxToken(t *testing.T) {
+	uc := newTestUseCase(&stubLLMProvider{reply: "hi"})
+
+	_, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello there, this is a longer message meant to exceed a tiny budget",
+		Config: ChatCompletionConfigInputDTO{
+			Model: "gpt-4o-mini",
+			ModelMaxToken: 1,
+			InitialSystemMessage: "you are a helpful assistant",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error once the prompt exceeds ModelMaxToken, got nil")
+	}
+}
@@ -0,0 +1,104 @@
+package chatcomplitionstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+// namedProviderResolver resolves to whichever stubLLMProvider was registered
+// under the requested name, so tests can tell which provider actually served
+// a given call.
+type namedProviderResolver struct {
+	providers map[string]gateway.LLMProvider
+}
+
+func (r namedProviderResolver) Provider(name string) (gateway.LLMProvider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, errors.New("unknown provider: " + name)
+	}
+	return provider, nil
+}
+
+func TestExecuteRoutesToTheConfiguredProvider(t *testing.T) {
+	providers := namedProviderResolver{providers: map[string]gateway.LLMProvider{
+		"openai":    &stubLLMProvider{reply: "from openai"},
+		"anthropic": &stubLLMProvider{reply: "from anthropic"},
+	}}
+	uc := newChatCompletionUseCase(newStubChatGateway(), providers, "openai", nil, nil, nil, make(chan ChatCompletionOutputDTO, 8))
+
+	out, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model:                "claude-3-5-sonnet-20241022",
+			InitialSystemMessage: "you are a helpful assistant",
+			Provider:             "anthropic",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Content != "from anthropic" {
+		t.Fatalf("expected the request's Provider to route the call, got %q", out.Content)
+	}
+}
+
+func TestExecuteContinuesOnTheChatsOriginalProvider(t *testing.T) {
+	providers := namedProviderResolver{providers: map[string]gateway.LLMProvider{
+		"openai":    &stubLLMProvider{reply: "from openai"},
+		"anthropic": &stubLLMProvider{reply: "from anthropic"},
+	}}
+	uc := newChatCompletionUseCase(newStubChatGateway(), providers, "openai", nil, nil, nil, make(chan ChatCompletionOutputDTO, 8))
+
+	if _, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model:                "claude-3-5-sonnet-20241022",
+			InitialSystemMessage: "you are a helpful assistant",
+			Provider:             "anthropic",
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error on the first turn: %v", err)
+	}
+
+	out, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "and now?",
+		Config:      ChatCompletionConfigInputDTO{Model: "claude-3-5-sonnet-20241022"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error on the second turn: %v", err)
+	}
+	if out.Content != "from anthropic" {
+		t.Fatalf("expected the chat to keep running on the provider it started on, got %q", out.Content)
+	}
+}
+
+func TestExecuteReturnsErrorForUnknownProvider(t *testing.T) {
+	providers := namedProviderResolver{providers: map[string]gateway.LLMProvider{
+		"openai": &stubLLMProvider{reply: "hi"},
+	}}
+	uc := newChatCompletionUseCase(newStubChatGateway(), providers, "openai", nil, nil, nil, make(chan ChatCompletionOutputDTO, 8))
+
+	_, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model:                "gpt-4o-mini",
+			InitialSystemMessage: "you are a helpful assistant",
+			Provider:             "does-not-exist",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error when Config.Provider names an unregistered provider")
+	}
+}
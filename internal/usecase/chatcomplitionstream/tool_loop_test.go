@@ -0,0 +1,103 @@
+package chatcomplitionstream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luanlsr/chatservice/internal/domain/entity"
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+// alwaysCallToolProvider asks for the same tool call on every turn, never
+// producing a final answer, to drive the MaxToolIterations bound.
+type alwaysCallToolProvider struct {
+	toolName string
+}
+
+func (p *alwaysCallToolProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	chunks := make(chan gateway.Chunk, 1)
+	chunks <- gateway.Chunk{Done: true, ToolCalls: []gateway.ToolCall{{ID: "call-1", Name: p.toolName, Arguments: "{}"}}}
+	close(chunks)
+	return chunks, nil
+}
+
+// callToolThenAnswerProvider asks for a tool call on its first turn, then
+// replies directly once the tool result comes back.
+type callToolThenAnswerProvider struct {
+	toolName string
+	calls    int
+}
+
+func (p *callToolThenAnswerProvider) StreamCompletion(ctx context.Context, req gateway.ChatRequest) (<-chan gateway.Chunk, error) {
+	p.calls++
+	chunks := make(chan gateway.Chunk, 2)
+	if p.calls == 1 {
+		chunks <- gateway.Chunk{Done: true, ToolCalls: []gateway.ToolCall{{ID: "call-1", Name: p.toolName, Arguments: "{}"}}}
+	} else {
+		chunks <- gateway.Chunk{Delta: "done"}
+		chunks <- gateway.Chunk{Done: true}
+	}
+	close(chunks)
+	return chunks, nil
+}
+
+func newTestUseCaseWithTools(provider gateway.LLMProvider, toolbox *entity.Toolbox) *ChatCompletionUseCase {
+	return newChatCompletionUseCase(newStubChatGateway(), singleProviderResolver{provider}, "stub", nil, toolbox, nil, make(chan ChatCompletionOutputDTO, 8))
+}
+
+func TestExecuteToolLoopExceedsMaxToolIterations(t *testing.T) {
+	toolbox := entity.NewToolbox(entity.Tool{
+		Name: "noop",
+		Impl: func(ctx context.Context, args map[string]any) (string, error) {
+			return "ok", nil
+		},
+	})
+	uc := newTestUseCaseWithTools(&alwaysCallToolProvider{toolName: "noop"}, toolbox)
+
+	_, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		AgentName:   "",
+		Config: ChatCompletionConfigInputDTO{
+			Model: "gpt-4o-mini",
+			InitialSystemMessage: "you are a helpful assistant",
+			Tools: []string{"noop"},
+			MaxToolIterations: 2,
+		},
+	})
+	if err == nil {
+		t.Fatal("expected the tool loop to bail out once MaxToolIterations is exceeded, got nil")
+	}
+}
+
+func TestExecuteToolLoopReturnsAfterToolResult(t *testing.T) {
+	toolbox := entity.NewToolbox(entity.Tool{
+		Name: "noop",
+		Impl: func(ctx context.Context, args map[string]any) (string, error) {
+			return "ok", nil
+		},
+	})
+	provider := &callToolThenAnswerProvider{toolName: "noop"}
+	uc := newTestUseCaseWithTools(provider, toolbox)
+
+	out, err := uc.Execute(context.Background(), ChatCompletionInputDTO{
+		ChatID:      "chat-1",
+		UserID:      "user-1",
+		UserMessage: "hello",
+		Config: ChatCompletionConfigInputDTO{
+			Model: "gpt-4o-mini",
+			InitialSystemMessage: "you are a helpful assistant",
+			Tools: []string{"noop"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Content != "done" {
+		t.Fatalf("expected the final answer after the tool round trip, got %q", out.Content)
+	}
+	if provider.calls != 2 {
+		t.Fatalf("expected exactly one tool round trip (2 provider calls), got %d", provider.calls)
+	}
+}
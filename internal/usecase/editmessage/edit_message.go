@@ -0,0 +1,60 @@
+package editmessage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/luanlsr/chatservice/internal/usecase/chatcomplitionstream"
+)
+
+type EditMessageInputDTO struct {
+	ChatID string
+	UserID string
+	MessageID string
+	NewContent string
+	AgentName string
+	Config chatcomplitionstream.ChatCompletionConfigInputDTO
+}
+
+// EditMessageUseCase reruns a completion from an earlier point in the
+// conversation instead of destroying history: it forks a new branch off the
+// edited message's parent and re-runs completion down that branch.
+type EditMessageUseCase struct {
+	ChatGateway gateway.ChatGateway
+	ChatCompletion *chatcomplitionstream.ChatCompletionUseCase
+}
+
+func newEditMessageUseCase(chatGateway gateway.ChatGateway, chatCompletion *chatcomplitionstream.ChatCompletionUseCase) *EditMessageUseCase {
+	return &EditMessageUseCase{
+		ChatGateway: chatGateway,
+		ChatCompletion: chatCompletion,
+	}
+}
+
+func (uc *EditMessageUseCase) Execute(ctx context.Context, input EditMessageInputDTO) (*chatcomplitionstream.ChatCompletionOutputDTO, error) {
+	chat, err := uc.ChatGateway.FindChatByID(ctx, input.ChatID)
+	if err != nil {
+		return nil, errors.New("error fetching chat: " + err.Error())
+	}
+
+	edited, ok := chat.MessagesByID[input.MessageID]
+	if !ok {
+		return nil, errors.New("message not found: " + input.MessageID)
+	}
+
+	if err := chat.Fork(edited.ParentID); err != nil {
+		return nil, errors.New("error forking chat: " + err.Error())
+	}
+	if err := uc.ChatGateway.SaveChat(ctx, chat); err != nil {
+		return nil, errors.New("error saving chat: " + err.Error())
+	}
+
+	return uc.ChatCompletion.Execute(ctx, chatcomplitionstream.ChatCompletionInputDTO{
+		ChatID: input.ChatID,
+		UserID: input.UserID,
+		UserMessage: input.NewContent,
+		AgentName: input.AgentName,
+		Config: input.Config,
+	})
+}
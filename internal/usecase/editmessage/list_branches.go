@@ -0,0 +1,58 @@
+package editmessage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+type ListBranchesInputDTO struct {
+	ChatID string
+	MessageID string
+}
+
+type BranchOutputDTO struct {
+	MessageID string
+	Content string
+	IsActive bool
+}
+
+// ListBranchesUseCase returns every sibling of MessageID (the alternative
+// edits/resends made from the same parent) so a UI can offer them as
+// switchable branches.
+type ListBranchesUseCase struct {
+	ChatGateway gateway.ChatGateway
+}
+
+func newListBranchesUseCase(chatGateway gateway.ChatGateway) *ListBranchesUseCase {
+	return &ListBranchesUseCase{ChatGateway: chatGateway}
+}
+
+func (uc *ListBranchesUseCase) Execute(ctx context.Context, input ListBranchesInputDTO) ([]BranchOutputDTO, error) {
+	chat, err := uc.ChatGateway.FindChatByID(ctx, input.ChatID)
+	if err != nil {
+		return nil, errors.New("error fetching chat: " + err.Error())
+	}
+
+	message, ok := chat.MessagesByID[input.MessageID]
+	if !ok {
+		return nil, errors.New("message not found: " + input.MessageID)
+	}
+
+	activePath := map[string]bool{}
+	for _, m := range chat.ActivePath() {
+		activePath[m.ID] = true
+	}
+
+	siblings := chat.Siblings(message.ParentID)
+	output := make([]BranchOutputDTO, 0, len(siblings))
+	for _, sibling := range siblings {
+		output = append(output, BranchOutputDTO{
+			MessageID: sibling.ID,
+			Content: sibling.Content,
+			IsActive: activePath[sibling.ID],
+		})
+	}
+	return output, nil
+}
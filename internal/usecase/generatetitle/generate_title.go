@@ -0,0 +1,67 @@
+package generatetitle
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/luanlsr/chatservice/internal/usecase/llmutil"
+)
+
+const titlePrompt = "Summarize this conversation into a short title of 3 to 6 words. Respond with only the title, no punctuation or quotes."
+
+type GenerateTitleInputDTO struct {
+	ChatID string
+}
+
+type GenerateTitleOutputDTO struct {
+	ChatID string
+	Title string
+}
+
+// GenerateTitleUseCase summarizes the user and assistant messages on a chat's
+// active path into a short title, run once there's something to summarize.
+type GenerateTitleUseCase struct {
+	ChatGateway gateway.ChatGateway
+	LLMProvider gateway.LLMProvider
+}
+
+func newGenerateTitleUseCase(chatGateway gateway.ChatGateway, llmProvider gateway.LLMProvider) *GenerateTitleUseCase {
+	return &GenerateTitleUseCase{
+		ChatGateway: chatGateway,
+		LLMProvider: llmProvider,
+	}
+}
+
+func (uc *GenerateTitleUseCase) Execute(ctx context.Context, input GenerateTitleInputDTO) (*GenerateTitleOutputDTO, error) {
+	chat, err := uc.ChatGateway.FindChatByID(ctx, input.ChatID)
+	if err != nil {
+		return nil, errors.New("error fetching chat: " + err.Error())
+	}
+
+	messages := []gateway.ChatRequestMessage{{Role: "system", Content: titlePrompt}}
+	for _, msg := range chat.ActivePath() {
+		if msg.Role != "user" && msg.Role != "assistant" {
+			continue
+		}
+		messages = append(messages, gateway.ChatRequestMessage{Role: msg.Role, Content: msg.Content})
+	}
+
+	title, err := llmutil.CompleteSync(ctx, uc.LLMProvider, gateway.ChatRequest{
+		Model: chat.Config.Model.Name,
+		Messages: messages,
+		MaxTokens: 16,
+	})
+	if err != nil {
+		return nil, errors.New("error generating title: " + err.Error())
+	}
+	title = strings.Trim(strings.TrimSpace(title), "\"")
+
+	chat.Title = title
+	if err := uc.ChatGateway.SaveChat(ctx, chat); err != nil {
+		return nil, errors.New("error saving chat: " + err.Error())
+	}
+
+	return &GenerateTitleOutputDTO{ChatID: chat.ID, Title: title}, nil
+}
@@ -0,0 +1,30 @@
+// Package llmutil holds small helpers shared across use-case packages that
+// talk to a gateway.LLMProvider directly, outside the main completion loop.
+package llmutil
+
+import (
+	"context"
+	"strings"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+)
+
+// CompleteSync drains a streamed completion into a single string, for
+// callers that need the whole answer before doing anything with it.
+func CompleteSync(ctx context.Context, provider gateway.LLMProvider, req gateway.ChatRequest) (string, error) {
+	chunks, err := provider.StreamCompletion(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	for chunk := range chunks {
+		if chunk.Done {
+			if chunk.Err != nil {
+				return "", chunk.Err
+			}
+			break
+		}
+		out.WriteString(chunk.Delta)
+	}
+	return out.String(), nil
+}
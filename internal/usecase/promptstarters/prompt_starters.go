@@ -0,0 +1,68 @@
+package promptstarters
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/luanlsr/chatservice/internal/domain/gateway"
+	"github.com/luanlsr/chatservice/internal/usecase/llmutil"
+)
+
+const maxStarters = 10
+
+type PromptStartersInputDTO struct {
+	Model string
+	InitialSystemMessage string
+	N int
+}
+
+type PromptStartersOutputDTO struct {
+	Starters []string
+}
+
+// PromptStartersUseCase suggests opening prompts for an empty chat, derived
+// from the system message / agent description it will be seeded with.
+type PromptStartersUseCase struct {
+	LLMProvider gateway.LLMProvider
+}
+
+func newPromptStartersUseCase(llmProvider gateway.LLMProvider) *PromptStartersUseCase {
+	return &PromptStartersUseCase{LLMProvider: llmProvider}
+}
+
+func (uc *PromptStartersUseCase) Execute(ctx context.Context, input PromptStartersInputDTO) (*PromptStartersOutputDTO, error) {
+	if input.N < 1 || input.N >= maxStarters {
+		return nil, errors.New("N must be between 1 and 9")
+	}
+
+	prompt := "Given the assistant described below, suggest " + strconv.Itoa(input.N) +
+		" short opening prompts a user might send. Respond with one per line, no numbering.\n\n" +
+		input.InitialSystemMessage
+
+	content, err := llmutil.CompleteSync(ctx, uc.LLMProvider, gateway.ChatRequest{
+		Model: input.Model,
+		Messages: []gateway.ChatRequestMessage{
+			{Role: "user", Content: prompt},
+		},
+		MaxTokens: 256,
+	})
+	if err != nil {
+		return nil, errors.New("error generating prompt starters: " + err.Error())
+	}
+
+	var starters []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		starters = append(starters, line)
+	}
+	if len(starters) > input.N {
+		starters = starters[:input.N]
+	}
+
+	return &PromptStartersOutputDTO{Starters: starters}, nil
+}